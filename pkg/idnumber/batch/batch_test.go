@@ -0,0 +1,54 @@
+package batch_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/svanellewee/idnumber/pkg/idnumber/batch"
+	_ "github.com/svanellewee/idnumber/pkg/idnumber/za"
+)
+
+func TestValidateReader(t *testing.T) {
+	input := strings.NewReader("8107095005083\nnot-an-id\n")
+	results, err := batch.ValidateReader(input, batch.WithWorkers(2))
+	assert.Nil(t, err)
+
+	valid, invalid := batch.Summary(results)
+	assert.Equal(t, 1, valid)
+	assert.Equal(t, 1, invalid)
+}
+
+func TestValidateReaderWithCountry(t *testing.T) {
+	input := strings.NewReader("8107095005083\n")
+	results, err := batch.ValidateReader(input, batch.WithCountry("za"))
+	assert.Nil(t, err)
+
+	valid, invalid := batch.Summary(results)
+	assert.Equal(t, 1, valid)
+	assert.Equal(t, 0, invalid)
+}
+
+func TestValidateReaderClampsNonPositiveWorkerCount(t *testing.T) {
+	input := strings.NewReader("8107095005083\n")
+	results, err := batch.ValidateReader(input, batch.WithWorkers(0))
+	assert.Nil(t, err)
+
+	valid, invalid := batch.Summary(results)
+	assert.Equal(t, 1, valid)
+	assert.Equal(t, 0, invalid)
+}
+
+func TestValidateReaderWithStrictRejectsImplausibleBirthdate(t *testing.T) {
+	input := strings.NewReader("8107095005083\n")
+	// A floor far in the future makes every real-world birthdate
+	// implausible, so this deterministically exercises the rejection
+	// path regardless of wall-clock time.
+	results, err := batch.ValidateReader(input, batch.WithStrict(time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Nil(t, err)
+
+	valid, invalid := batch.Summary(results)
+	assert.Equal(t, 0, valid)
+	assert.Equal(t, 1, invalid)
+}
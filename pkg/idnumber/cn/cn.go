@@ -0,0 +1,275 @@
+// Package cn parses, validates and generates Chinese resident identity
+// card numbers per GB 11643: an 18-character string laid out as
+// AAAAAA YYYYMMDD NNN C, where AAAAAA is a GB/T 2260 administrative
+// division code, YYYYMMDD is the birthdate, NNN is a sequence code
+// (odd = male, even = female) and C is an ISO 7064:1983 MOD 11-2 check
+// character.
+package cn
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/svanellewee/idnumber/pkg/idnumber"
+)
+
+const (
+	idLength       = 18
+	regionLength   = 6
+	dateLength     = 8
+	sequenceIndex  = 14
+	sequenceLength = 3
+	checkIndex     = 17
+)
+
+// weights are the ISO 7064:1983 MOD 11-2 weights applied to the first
+// 17 digits, left to right.
+var weights = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+
+// checkDigits maps sum%11 to the check character.
+var checkDigits = [11]byte{'1', '0', 'X', '9', '8', '7', '6', '5', '4', '3', '2'}
+
+// provinceCodes are the known GB/T 2260 province-level prefixes (first
+// two digits of the region code).
+var provinceCodes = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true,
+	"21": true, "22": true, "23": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "36": true, "37": true,
+	"41": true, "42": true, "43": true, "44": true, "45": true, "46": true,
+	"50": true, "51": true, "52": true, "53": true, "54": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true,
+	"71": true, "81": true, "82": true, "91": true,
+}
+
+func init() {
+	idnumber.Register("cn", func(id string) (idnumber.IDNumber, error) {
+		return NewIDNumber(SetFromString(id))
+	})
+}
+
+// Gender is an enumeration of the sex encoded by the odd/even sequence
+// code in a Chinese ID number.
+type Gender int
+
+const (
+	// Male is encoded by an odd sequence code.
+	Male Gender = iota
+	// Female is encoded by an even sequence code.
+	Female
+)
+
+func (g Gender) String() string {
+	if g == Male {
+		return "male"
+	}
+	return "female"
+}
+
+// IDNumber is the structure that contains the meaning behind a Chinese
+// resident identity card number.
+type IDNumber struct {
+	region     string
+	birthdate  time.Time
+	sequence   int
+	checkDigit byte
+}
+
+var (
+	// ErrIncorrectIDStringLength is returned when the id string is not
+	// exactly 18 characters long.
+	ErrIncorrectIDStringLength = fmt.Errorf("incorrect ID string length")
+	// ErrUnknownProvince is returned when the first two digits don't
+	// match a known GB/T 2260 province-level code.
+	ErrUnknownProvince = fmt.Errorf("unknown province code")
+	// ErrInvalidCheckDigit is returned when the trailing check character
+	// doesn't match the ISO 7064:1983 MOD 11-2 checksum of the rest.
+	ErrInvalidCheckDigit = fmt.Errorf("invalid check digit")
+)
+
+// Region returns the six-digit GB/T 2260 administrative division code.
+func (id IDNumber) Region() string {
+	return id.region
+}
+
+// Birthdate returns the date of birth encoded in the ID number.
+func (id IDNumber) Birthdate() time.Time {
+	return id.birthdate
+}
+
+// Gender returns the sex encoded by the sequence code.
+func (id IDNumber) Gender() Gender {
+	if id.sequence%2 == 0 {
+		return Female
+	}
+	return Male
+}
+
+func (id IDNumber) String() string {
+	return fmt.Sprintf("%s%s%03d%c", id.region, id.birthdate.Format("20060102"), id.sequence, id.checkDigit)
+}
+
+// Explain prints out a more verbose explanation of what the ID number means.
+func (id IDNumber) Explain() string {
+	return fmt.Sprintf("Region: %s Birthdate: %s %s check digit = %c", id.region, id.birthdate.Format("2006-01-02"), id.Gender(), id.checkDigit)
+}
+
+// Validate re-derives the ISO 7064:1983 MOD 11-2 check digit from the
+// other fields and compares it against the one stored on the ID number.
+func (id IDNumber) Validate() error {
+	if !validRegion(id.region) {
+		return ErrUnknownProvince
+	}
+	expected := calculateCheckDigit(id.region + id.birthdate.Format("20060102") + fmt.Sprintf("%03d", id.sequence))
+	if expected != id.checkDigit {
+		return ErrInvalidCheckDigit
+	}
+	return nil
+}
+
+// validRegion reports whether region is a six-digit GB/T 2260 code whose
+// first two digits are a known province-level prefix.
+func validRegion(region string) bool {
+	return len(region) == regionLength && provinceCodes[region[0:2]]
+}
+
+func calculateCheckDigit(first17 string) byte {
+	sum := 0
+	for i, w := range weights {
+		digit := int(first17[i] - '0')
+		sum += digit * w
+	}
+	return checkDigits[sum%11]
+}
+
+// ConfigOption provides a way to configure a new IDNumber object.
+type ConfigOption func(id *IDNumber) error
+
+// SetRegion sets the six-digit GB/T 2260 administrative division code.
+func SetRegion(region string) ConfigOption {
+	return func(idNumber *IDNumber) error {
+		idNumber.region = region
+		return nil
+	}
+}
+
+// SetDate sets the date of a person's birth.
+func SetDate(day int, month time.Month, year int) ConfigOption {
+	return func(idNumber *IDNumber) error {
+		idNumber.birthdate = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return nil
+	}
+}
+
+// SetSequence sets the three-digit sequence code directly; odd values
+// encode Male, even values encode Female.
+func SetSequence(sequence int) ConfigOption {
+	return func(idNumber *IDNumber) error {
+		idNumber.sequence = sequence
+		return nil
+	}
+}
+
+// SetGender picks a random sequence code of the given gender.
+func SetGender(gender Gender) ConfigOption {
+	sequence := rand.Intn(500) * 2
+	if gender == Male {
+		sequence++
+	}
+	return SetSequence(sequence)
+}
+
+// SetFromString takes an 18-character id number string and creates an
+// IDNumber from it.
+func SetFromString(id string) ConfigOption {
+	return func(idNumber *IDNumber) error {
+		if len(id) != idLength {
+			return ErrIncorrectIDStringLength
+		}
+		region := id[0:regionLength]
+		if !validRegion(region) {
+			return ErrUnknownProvince
+		}
+		t, err := time.Parse("20060102", id[regionLength:regionLength+dateLength])
+		if err != nil {
+			return err
+		}
+		sequence, err := strconv.Atoi(id[sequenceIndex : sequenceIndex+sequenceLength])
+		if err != nil {
+			return err
+		}
+
+		idNumber.region = region
+		idNumber.birthdate = t
+		idNumber.sequence = sequence
+		idNumber.checkDigit = id[checkIndex]
+		return nil
+	}
+}
+
+// NewIDNumber builds a new IDNumber, computing the check digit from the
+// other fields unless SetFromString already supplied one, in which case
+// it's verified instead.
+func NewIDNumber(configOptions ...ConfigOption) (*IDNumber, error) {
+	idNumber := &IDNumber{}
+	for _, configOption := range configOptions {
+		if err := configOption(idNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	if !validRegion(idNumber.region) {
+		return nil, ErrUnknownProvince
+	}
+
+	first17 := idNumber.region + idNumber.birthdate.Format("20060102") + fmt.Sprintf("%03d", idNumber.sequence)
+	checkDigit := calculateCheckDigit(first17)
+	if idNumber.checkDigit != 0 {
+		if idNumber.checkDigit != checkDigit {
+			return nil, ErrInvalidCheckDigit
+		}
+	} else {
+		idNumber.checkDigit = checkDigit
+	}
+	return idNumber, nil
+}
+
+// NewID builds a new ID number with a simple builder.
+func NewID(day int, month time.Month, year int, region string, sequence int) (*IDNumber, error) {
+	return NewIDNumber(
+		SetRegion(region),
+		SetDate(day, month, year),
+		SetSequence(sequence),
+	)
+}
+
+// RandomIDNumber creates a random valid IDNumber, picking a random
+// known province and a birthdate between 1970 and 2070.
+func RandomIDNumber() (*IDNumber, error) {
+	regions := make([]string, 0, len(provinceCodes))
+	for region := range provinceCodes {
+		regions = append(regions, region)
+	}
+	province := regions[rand.Intn(len(regions))]
+	region := fmt.Sprintf("%s%04d", province, rand.Intn(10000))
+
+	min := time.Date(1970, 1, 0, 0, 0, 0, 0, time.UTC).Unix()
+	max := time.Date(2070, 1, 0, 0, 0, 0, 0, time.UTC).Unix()
+	delta := max - min
+	sec := rand.Int63n(delta) + min
+	randomDate := time.Unix(sec, 0)
+
+	var genderOption ConfigOption
+	if rand.Intn(2) == 0 {
+		genderOption = SetGender(Female)
+	} else {
+		genderOption = SetGender(Male)
+	}
+
+	return NewIDNumber(
+		SetRegion(region),
+		SetDate(randomDate.Day(), randomDate.Month(), randomDate.Year()),
+		genderOption,
+	)
+}
@@ -0,0 +1,42 @@
+package cn_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/svanellewee/idnumber/pkg/idnumber/cn"
+)
+
+func TestChineseIDNumber(t *testing.T) {
+	dude, err := cn.NewID(1, 9, 1980, "110101", 123)
+	assert.Nil(t, err)
+	assert.Equal(t, "110101198009011237", dude.String())
+	fmt.Printf("%v..\n", dude)
+	assert.Nil(t, dude.Validate())
+
+	for i := 0; i < 10; i++ {
+		id, err := cn.RandomIDNumber()
+		assert.Nil(t, err)
+		fmt.Printf("%s %s\n", id, id.Explain())
+	}
+
+	idValue, err := cn.NewIDNumber(cn.SetFromString("110101198009011237"))
+	assert.Nil(t, err)
+	assert.Equal(t, dude.String(), idValue.String())
+}
+
+func TestChineseIDNumberUnknownProvince(t *testing.T) {
+	_, err := cn.NewIDNumber(cn.SetFromString("990101198009011230"))
+	assert.Equal(t, cn.ErrUnknownProvince, err)
+}
+
+func TestChineseIDNumberInvalidCheckDigit(t *testing.T) {
+	_, err := cn.NewIDNumber(cn.SetFromString("110101198009011230"))
+	assert.Equal(t, cn.ErrInvalidCheckDigit, err)
+}
+
+func TestChineseIDNumberRejectsMissingRegion(t *testing.T) {
+	_, err := cn.NewIDNumber(cn.SetDate(1, 9, 1980), cn.SetSequence(123))
+	assert.Equal(t, cn.ErrUnknownProvince, err)
+}
@@ -0,0 +1,121 @@
+// Package config loads a declarative generation policy for ID numbers
+// from HCL or YAML, so downstream tools (test data generators, migration
+// scripts) can describe bulk-generation profiles without touching code.
+package config
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl"
+	"gopkg.in/yaml.v2"
+
+	"github.com/svanellewee/idnumber/pkg/idnumber/za"
+)
+
+// Config describes the policy RandomIDNumber-style generators should
+// follow: the date range to draw birthdates from, the gender and
+// citizen/resident splits, and an optional RNG seed for reproducible
+// bulk runs.
+type Config struct {
+	DateFrom       string `hcl:"date_from" yaml:"date_from"`
+	DateTo         string `hcl:"date_to" yaml:"date_to"`
+	FemaleWeight   int    `hcl:"female_weight" yaml:"female_weight"`
+	ResidentWeight int    `hcl:"resident_weight" yaml:"resident_weight"`
+	Seed           int64  `hcl:"seed" yaml:"seed"`
+
+	dateFrom time.Time
+	dateTo   time.Time
+	rng      *rand.Rand
+}
+
+// defaults mirror za.RandomIDNumber's hardcoded 1970-2070 range and
+// 50/50 splits, so LoadConfig with a mostly-empty file behaves the same
+// as calling za.RandomIDNumber directly.
+const (
+	defaultDateFrom       = "1970-01-01"
+	defaultDateTo         = "2070-01-01"
+	defaultFemaleWeight   = 50
+	defaultResidentWeight = 50
+)
+
+// LoadConfig reads a generation policy from path. HCL and YAML are both
+// accepted; the format is picked from the file extension (".hcl" vs
+// ".yml"/".yaml").
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		DateFrom:       defaultDateFrom,
+		DateTo:         defaultDateTo,
+		FemaleWeight:   defaultFemaleWeight,
+		ResidentWeight: defaultResidentWeight,
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, err
+		}
+	default:
+		if err := hcl.Unmarshal(data, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	dateFrom, err := time.Parse("2006-01-02", cfg.DateFrom)
+	if err != nil {
+		return nil, err
+	}
+	dateTo, err := time.Parse("2006-01-02", cfg.DateTo)
+	if err != nil {
+		return nil, err
+	}
+	if !dateFrom.Before(dateTo) {
+		return nil, fmt.Errorf("config: date_from (%s) must be before date_to (%s)", cfg.DateFrom, cfg.DateTo)
+	}
+	cfg.dateFrom = dateFrom
+	cfg.dateTo = dateTo
+
+	if cfg.Seed != 0 {
+		cfg.rng = rand.New(rand.NewSource(cfg.Seed))
+	} else {
+		cfg.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return cfg, nil
+}
+
+// Random generates a South African IDNumber following this Config's
+// date range, gender weight and citizen/resident weight.
+func (c *Config) Random() (*za.IDNumber, error) {
+	delta := c.dateTo.Unix() - c.dateFrom.Unix()
+	sec := c.rng.Int63n(delta) + c.dateFrom.Unix()
+	randomDate := time.Unix(sec, 0).UTC()
+
+	var genderOption za.ConfigOption
+	if c.rng.Intn(100) < c.FemaleWeight {
+		genderOption = za.SetGender(za.GenderCode(c.rng.Intn(4999)))
+	} else {
+		genderOption = za.SetGender(za.GenderCode(5000 + c.rng.Intn(4999)))
+	}
+
+	var citizenOption za.ConfigOption
+	if c.rng.Intn(100) < c.ResidentWeight {
+		citizenOption = za.SetResident()
+	} else {
+		citizenOption = za.SetCitizen()
+	}
+
+	return za.NewIDNumber(
+		za.SetDate(randomDate.Day(), randomDate.Month(), randomDate.Year()),
+		genderOption,
+		citizenOption,
+	)
+}
@@ -0,0 +1,33 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/svanellewee/idnumber/pkg/idnumber/config"
+)
+
+func TestLoadConfigFromYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	contents := []byte("date_from: \"1990-01-01\"\ndate_to: \"2000-01-01\"\nfemale_weight: 100\nresident_weight: 0\nseed: 7\n")
+	assert.Nil(t, os.WriteFile(path, contents, 0o600))
+
+	cfg, err := config.LoadConfig(path)
+	assert.Nil(t, err)
+
+	id, err := cfg.Random()
+	assert.Nil(t, err)
+	assert.Equal(t, "female", id.Gender().String())
+	assert.True(t, id.Birthdate().Year() >= 1990 && id.Birthdate().Year() <= 2000)
+}
+
+func TestLoadConfigRejectsInvertedDateRange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	contents := []byte("date_from: \"2000-01-01\"\ndate_to: \"1990-01-01\"\n")
+	assert.Nil(t, os.WriteFile(path, contents, 0o600))
+
+	_, err := config.LoadConfig(path)
+	assert.NotNil(t, err)
+}
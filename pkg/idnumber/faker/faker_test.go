@@ -0,0 +1,25 @@
+package faker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/svanellewee/idnumber/pkg/idnumber/faker"
+	"github.com/svanellewee/idnumber/pkg/idnumber/za"
+)
+
+type User struct {
+	Name string
+	ID   string `idnumber:"za,male,resident"`
+}
+
+func TestFillIsReproducibleForASeed(t *testing.T) {
+	var a, b User
+	assert.Nil(t, faker.New(42).Fill(&a))
+	assert.Nil(t, faker.New(42).Fill(&b))
+	assert.Equal(t, a.ID, b.ID)
+
+	parsed, err := za.NewIDNumber(za.SetFromString(a.ID))
+	assert.Nil(t, err)
+	assert.Equal(t, za.PermanentResident, parsed.Citizenship())
+}
@@ -0,0 +1,392 @@
+// Package za parses, validates and generates South African ID numbers.
+package za
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/theplant/luhn"
+
+	"github.com/svanellewee/idnumber/pkg/idnumber"
+)
+
+const (
+	minFemale = 0
+	maxFemale = 4999
+	maxMale   = 9999
+	minMale   = 5000
+
+	// goDefaultPivot matches the century pivot time.Parse("06", ...) uses
+	// internally, so SetFromString's behaviour is unchanged unless the
+	// caller opts into SetCenturyWindow or SetReferenceDate.
+	goDefaultPivot = 68
+)
+
+func randomFemale() GenderCode {
+	return GenderCode(minFemale + rand.Intn(4999))
+}
+
+func randomMale() GenderCode {
+	return GenderCode(minMale + rand.Intn(4999))
+}
+
+func init() {
+	idnumber.Register("za", func(id string) (idnumber.IDNumber, error) {
+		return NewIDNumber(SetFromString(id))
+	})
+}
+
+// Citizenship is an enumeration of either South African Citizens or Permanent Residents
+type Citizenship int
+
+const (
+	// Citizen is the default South African Citizen
+	Citizen Citizenship = iota
+	// PermanentResident is the alternative
+	PermanentResident
+)
+
+func (c Citizenship) String() string {
+	switch c {
+	case Citizen:
+		return "citizen"
+	case PermanentResident:
+		return "permanent resident"
+	default:
+		return "undefined citizenship"
+	}
+}
+
+// GenderCode saves the number ranges that represents "sex" (Used Gender here, perhaps incorrectly)
+type GenderCode int
+
+// IDNumber is the structure that contains the meaning behind the long South African ID number string
+type IDNumber struct {
+	birthdate   time.Time
+	gender      GenderCode
+	citizenship Citizenship
+	luhnValue   int
+}
+
+var (
+	ErrIncorrectIDStringLength = fmt.Errorf("incorrect ID string length")
+	ErrIncorrectGenderRange    = fmt.Errorf("incorrect gender range")
+	ErrInvalidLuhnNumber       = fmt.Errorf("invalid luhn number")
+	// ErrInvalidDate is returned by SetFromString when the embedded
+	// month/day don't form a real calendar date (e.g. month 13, day 32).
+	ErrInvalidDate = fmt.Errorf("invalid date")
+	// ErrImplausibleBirthdate is returned by SetFromString under
+	// StrictDates when the resolved birthdate lies in the future or
+	// before the configured floor (1900 by default).
+	ErrImplausibleBirthdate = fmt.Errorf("implausible birthdate")
+)
+
+func (g GenderCode) String() string {
+	if g >= minFemale && g <= maxFemale {
+		return "female"
+	} else if g >= minMale && g <= maxMale {
+		return "male"
+	}
+	return "undefined"
+}
+
+// Citizenship provides the person's citizen status
+func (id IDNumber) Citizenship() Citizenship {
+	return id.citizenship
+}
+
+// Birthdate returns the date of birth encoded in the ID number.
+func (id IDNumber) Birthdate() time.Time {
+	return id.birthdate
+}
+
+// Gender returns the sex encoded in the ID number.
+func (id IDNumber) Gender() GenderCode {
+	return id.gender
+}
+
+func (id IDNumber) String() string {
+	return fmt.Sprintf("%s%0.4d%d8%d", id.birthdate.Format("060102"), id.gender, id.citizenship, id.luhnValue)
+}
+
+// Explain prints out a more verbose explanation of what the ID number means
+func (id IDNumber) Explain() string {
+	return fmt.Sprintf("Birthdate: %s %s %s luhn checksum = %d", id.birthdate.Format("2 January '06"), id.gender, id.citizenship, id.luhnValue)
+}
+
+// Validate re-derives the Luhn checksum from the other fields and
+// compares it against the one stored on the ID number, catching
+// hand-tampered or corrupted values.
+func (id IDNumber) Validate() error {
+	partialString := fmt.Sprintf("%s%0.4d%d8", id.birthdate.Format("060102"), id.gender, id.citizenship)
+	partialID, err := strconv.ParseInt(partialString, 10, 64)
+	if err != nil {
+		return err
+	}
+	if luhn.CalculateLuhn(int(partialID)) != id.luhnValue {
+		return ErrInvalidLuhnNumber
+	}
+	return nil
+}
+
+// ConfigOption provides a way to configure a new IDNumber object
+type ConfigOption func(id *IDNumber) error
+
+// SetDate provides a way of setting the date of a person's birth
+func SetDate(day int, month time.Month, year int) ConfigOption {
+	return func(idNumber *IDNumber) error {
+		idNumber.birthdate = time.Date(year, month, day, 0, 0, 0, 0, &time.Location{})
+		return nil
+	}
+}
+
+// SetGender sets the sex of the person
+func SetGender(gender GenderCode) ConfigOption {
+	return func(idNumber *IDNumber) error {
+		idNumber.gender = gender
+		return nil
+	}
+}
+
+// SetRandomMale creates a random number that will indicate male IDNumbers
+func SetRandomMale() ConfigOption {
+	code := randomMale()
+	return SetGender(code)
+}
+
+// SetRandomFemale creates a random number that will indicate female IDNumbers
+func SetRandomFemale() ConfigOption {
+	code := randomFemale()
+	return SetGender(code)
+}
+
+func setCitizenship(citizenship Citizenship) ConfigOption {
+	return func(idNumber *IDNumber) error {
+		idNumber.citizenship = citizenship
+		return nil
+	}
+}
+
+// SetCitizen sets the IDNumber to an natural South African Citizen
+func SetCitizen() ConfigOption {
+	return setCitizenship(Citizen)
+}
+
+// SetResident sets the IDNumber to a Permanent Resident
+func SetResident() ConfigOption {
+	return setCitizenship(PermanentResident)
+}
+
+// DateOption configures how SetFromString resolves the ambiguous
+// two-digit year embedded in a South African ID number.
+type DateOption func(*dateConfig)
+
+type dateConfig struct {
+	pivot       int
+	reference   time.Time
+	strictDates bool
+	dateFloor   time.Time
+}
+
+// SetCenturyWindow sets the pivot used to resolve a two-digit year:
+// years <= pivot resolve to 20YY, years > pivot resolve to 19YY. Without
+// this option SetFromString keeps time.Parse's own pivot of 68, so a
+// person born in '24 is read as 2024 and one born in '81 as 1981.
+func SetCenturyWindow(pivot int) DateOption {
+	return func(c *dateConfig) {
+		c.pivot = pivot
+	}
+}
+
+// SetReferenceDate anchors century resolution to a specific point in
+// time instead of a fixed pivot: a two-digit year is read as belonging
+// to reference's century unless that would make the year later than
+// reference's year, in which case it's read as the century before. Only
+// the year is compared; a birthdate later in reference's own year keeps
+// reference's century.
+func SetReferenceDate(reference time.Time) DateOption {
+	return func(c *dateConfig) {
+		c.reference = reference
+	}
+}
+
+// StrictDates rejects birthdates that lie in the future or before
+// floor (1900-01-01 if floor is the zero value), returning
+// ErrImplausibleBirthdate instead of silently accepting them.
+func StrictDates(floor time.Time) DateOption {
+	return func(c *dateConfig) {
+		c.strictDates = true
+		c.dateFloor = floor
+	}
+}
+
+func resolveYear(twoDigit string, cfg dateConfig) (int, error) {
+	yy, err := strconv.Atoi(twoDigit)
+	if err != nil {
+		return 0, err
+	}
+	if !cfg.reference.IsZero() {
+		refYY := cfg.reference.Year() % 100
+		refCentury := (cfg.reference.Year() / 100) * 100
+		if yy <= refYY {
+			return refCentury + yy, nil
+		}
+		return refCentury - 100 + yy, nil
+	}
+	if yy <= cfg.pivot {
+		return 2000 + yy, nil
+	}
+	return 1900 + yy, nil
+}
+
+// SetFromString takes an id number string and creates an IDNumber from
+// it. By default, the two-digit year embedded in id is resolved with
+// the same pivot time.Parse("06", ...) uses; pass SetCenturyWindow or
+// SetReferenceDate to control that explicitly, and StrictDates to
+// reject implausible results.
+func SetFromString(id string, opts ...DateOption) ConfigOption {
+	// YYMMDD GGGG C  8 L
+	//                |
+	//             legacy bit, always there, ignore.
+	const (
+		dateIndex     = 0
+		dateLength    = 6
+		genderIndex   = 6
+		genderLength  = 4
+		citizenIndex  = 10
+		citizenLength = 1
+		luhnIndex     = 12
+		luhnLength    = 1
+		idLength      = 13
+	)
+
+	cfg := dateConfig{pivot: goDefaultPivot}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(idNumber *IDNumber) error {
+		if len(id) != idLength {
+			return ErrIncorrectIDStringLength
+		}
+		dateString := id[dateIndex : dateIndex+dateLength]
+		year, err := resolveYear(dateString[0:2], cfg)
+		if err != nil {
+			return err
+		}
+		month, err := strconv.Atoi(dateString[2:4])
+		if err != nil {
+			return err
+		}
+		day, err := strconv.Atoi(dateString[4:6])
+		if err != nil {
+			return err
+		}
+		birthdate := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if birthdate.Year() != year || int(birthdate.Month()) != month || birthdate.Day() != day {
+			return ErrInvalidDate
+		}
+		idNumber.birthdate = birthdate
+
+		if cfg.strictDates {
+			floor := cfg.dateFloor
+			if floor.IsZero() {
+				floor = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+			}
+			if idNumber.birthdate.After(time.Now()) || idNumber.birthdate.Before(floor) {
+				return ErrImplausibleBirthdate
+			}
+		}
+
+		genderCode, err := strconv.ParseInt(id[genderIndex:genderIndex+genderLength], 10, 32)
+		if err != nil {
+			return err
+		}
+		idNumber.gender = GenderCode(genderCode)
+
+		citizenship, err := strconv.ParseInt(id[citizenIndex:citizenIndex+citizenLength], 10, 32)
+		if err != nil {
+			return err
+		}
+		idNumber.citizenship = Citizenship(citizenship)
+
+		luhnNumber, err := strconv.ParseInt(id[luhnIndex:luhnIndex+luhnLength], 10, 32)
+		if err != nil {
+			return err
+		}
+		idNumber.luhnValue = int(luhnNumber)
+		return nil
+	}
+}
+
+// NewIDNumber builds a new IDNUmber
+func NewIDNumber(configOptions ...ConfigOption) (*IDNumber, error) {
+	idNumber := &IDNumber{
+		luhnValue: -1,
+	}
+	for _, configOption := range configOptions {
+		err := configOption(idNumber)
+		if err != nil {
+			return nil, err
+		}
+	}
+	partialString := fmt.Sprintf("%s%0.4d%d8", idNumber.birthdate.Format("060102"), idNumber.gender, idNumber.citizenship)
+	partialID, err := strconv.ParseInt(partialString, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	luhnValue := luhn.CalculateLuhn(int(partialID))
+	if idNumber.luhnValue != -1 {
+		if idNumber.luhnValue != luhnValue {
+			return nil, ErrInvalidLuhnNumber
+		}
+	} else {
+		idNumber.luhnValue = luhnValue
+	}
+	return idNumber, nil
+}
+
+// NewID builds a new ID number with a simple builder
+func NewID(day int, month time.Month, year int, gender GenderCode, citizenship Citizenship) (*IDNumber, error) {
+	return NewIDNumber(
+		SetDate(day, month, year),
+		SetGender(gender),
+		setCitizenship(citizenship),
+	)
+}
+
+// RandomIDNumber *should* create a random valid IDNumber
+func RandomIDNumber() (*IDNumber, error) {
+	min := time.Date(1970, 1, 0, 0, 0, 0, 0, time.UTC).Unix()
+	max := time.Date(2070, 1, 0, 0, 0, 0, 0, time.UTC).Unix()
+	delta := max - min
+
+	sec := rand.Int63n(delta) + min
+	randomDate := time.Unix(sec, 0)
+
+	var genderOption ConfigOption
+	if rand.Intn(100) > 50 {
+		genderOption = SetRandomFemale()
+	} else {
+		genderOption = SetRandomMale()
+	}
+
+	var citizenOption ConfigOption
+	if rand.Intn(100) > 50 {
+		citizenOption = SetCitizen()
+	} else {
+		citizenOption = SetResident()
+	}
+	retID, err := NewIDNumber(
+		SetDate(randomDate.Day(), randomDate.Month(), randomDate.Year()),
+		genderOption,
+		citizenOption,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return retID, nil
+}
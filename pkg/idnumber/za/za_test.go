@@ -0,0 +1,51 @@
+package za_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/svanellewee/idnumber/pkg/idnumber/za"
+)
+
+func TestSouthAfricanIDNumber(t *testing.T) {
+	// Checking if a string is a valid luhn
+	dude, err := za.NewID(9, 7, 1981, 5005, za.Citizen)
+	assert.Nil(t, err)
+	assert.Equal(t, "8107095005083", dude.String())
+	fmt.Printf("%v..\n", dude)
+	for i := 0; i < 10; i++ {
+		id, err := za.RandomIDNumber()
+		assert.Nil(t, err)
+		fmt.Printf("%s %s\n", id, id.Explain())
+	}
+	idValue, err := za.NewIDNumber(za.SetFromString("8107095005083"))
+	assert.Nil(t, err)
+	assert.Equal(t, dude.String(), idValue.String())
+	assert.Nil(t, idValue.Validate())
+}
+
+func TestSetFromStringCenturyWindow(t *testing.T) {
+	// '81 falls after the default pivot of 68, so it resolves to 1981.
+	dude, err := za.NewIDNumber(za.SetFromString("8107095005083"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1981, dude.Birthdate().Year())
+
+	// With a pivot of 99 every two-digit year resolves to the 2000s.
+	youngDude, err := za.NewIDNumber(za.SetFromString("8107095005083", za.SetCenturyWindow(99)))
+	assert.Nil(t, err)
+	assert.Equal(t, 2081, youngDude.Birthdate().Year())
+}
+
+func TestSetFromStringStrictDates(t *testing.T) {
+	_, err := za.NewIDNumber(za.SetFromString("8107095005083", za.SetCenturyWindow(99), za.StrictDates(time.Time{})))
+	assert.Equal(t, za.ErrImplausibleBirthdate, err)
+}
+
+func TestSetFromStringRejectsImpossibleDate(t *testing.T) {
+	// month 13, day 32: time.Date would normalize this instead of
+	// erroring, so SetFromString must catch it explicitly.
+	_, err := za.NewIDNumber(za.SetFromString("8113320000085"))
+	assert.Equal(t, za.ErrInvalidDate, err)
+}